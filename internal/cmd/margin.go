@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	"github.com/adityaxdiwakar/helios/internal/margin"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	marginSinceDays int
+	marginPush      bool
+)
+
+var marginCmd = &cobra.Command{
+	Use:   "margin",
+	Short: "Inspect margin loans, repayments, and accrued interest",
+}
+
+var marginHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Print margin loan/repay/interest records since a cutoff",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since := time.Now().AddDate(0, 0, -marginSinceDays)
+		r := ledger.NewReader(cfg.Ledger.Binary, cfg.LedgerFile())
+
+		h, err := margin.GetMarginHistory(r, since)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range h.Loans {
+			fmt.Printf("loan     %s %s principal=%.2f\n", r.Timestamp.Format("2006-01-02"), r.Asset, r.Principal)
+		}
+		for _, r := range h.Repays {
+			fmt.Printf("repay    %s %s principal=%.2f\n", r.Timestamp.Format("2006-01-02"), r.Asset, r.Principal)
+		}
+		for _, r := range h.Interest {
+			fmt.Printf("interest %s %s amount=%.2f\n", r.Timestamp.Format("2006-01-02"), r.Asset, r.Amount)
+		}
+
+		if marginPush {
+			client := influxdb2.NewClient(cfg.Influx.URL, cfg.Influx.Token)
+			defer client.Close()
+			writeApi := client.WriteAPI(cfg.Influx.Org, cfg.Influx.Bucket)
+			for _, p := range h.Points() {
+				writeApi.WritePoint(p)
+			}
+			writeApi.Flush()
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	marginHistoryCmd.Flags().IntVar(&marginSinceDays, "since-days", 365, "how many days of history to walk")
+	marginHistoryCmd.Flags().BoolVar(&marginPush, "push", false, "also push margin points to InfluxDB")
+	marginCmd.AddCommand(marginHistoryCmd)
+	rootCmd.AddCommand(marginCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	"github.com/adityaxdiwakar/helios/internal/pnl"
+	"github.com/adityaxdiwakar/helios/internal/price"
+	"github.com/adityaxdiwakar/helios/internal/sync"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/spf13/cobra"
+)
+
+var pnlPush bool
+
+var pnlCmd = &cobra.Command{
+	Use:   "pnl",
+	Short: "Compute realized/unrealized PnL and a buy-and-hold benchmark",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := ledger.NewReader(cfg.Ledger.Binary, cfg.LedgerFile())
+		now := time.Now()
+
+		trades, err := pnl.GetTrades(r, sync.Accounts)
+		if err != nil {
+			return err
+		}
+		dividends, err := pnl.GetDividends(r, time.Time{})
+		if err != nil {
+			return err
+		}
+		prices, err := price.ReadPriceDB(cfg.PriceDB())
+		if err != nil {
+			return err
+		}
+
+		method := pnl.FIFO
+		if cfg.PnL.CostBasisMethod == string(pnl.Average) {
+			method = pnl.Average
+		}
+
+		results := pnl.Compute(trades, dividends, prices, method, now)
+		for _, r := range results {
+			fmt.Printf("%-6s realized=%.2f unrealized=%.2f realized_ytd=%.2f dividends=%.2f\n",
+				r.Ticker, r.Realized, r.Unrealized, r.RealizedYTD, r.Dividends)
+		}
+
+		benchmarkMarket, ok := pnl.SimulateBenchmark(trades, prices, cfg.PnL.BenchmarkSymbol, now)
+		if ok {
+			fmt.Printf("benchmark(%s) market=%.2f\n", cfg.PnL.BenchmarkSymbol, benchmarkMarket)
+		}
+
+		if pnlPush {
+			client := influxdb2.NewClient(cfg.Influx.URL, cfg.Influx.Token)
+			defer client.Close()
+			writeApi := client.WriteAPI(cfg.Influx.Org, cfg.Influx.Bucket)
+			for _, p := range pnl.Points(sync.Accounts, results) {
+				writeApi.WritePoint(p)
+			}
+			if ok {
+				writeApi.WritePoint(pnl.BenchmarkPoint(sync.Accounts, cfg.PnL.BenchmarkSymbol, benchmarkMarket))
+			}
+			writeApi.Flush()
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	pnlCmd.Flags().BoolVar(&pnlPush, "push", false, "also push PnL points to InfluxDB")
+	rootCmd.AddCommand(pnlCmd)
+}
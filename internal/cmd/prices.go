@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	"github.com/adityaxdiwakar/helios/internal/price"
+	"github.com/adityaxdiwakar/helios/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var pricesCmd = &cobra.Command{
+	Use:   "prices",
+	Short: "Manage the local ledger price database",
+}
+
+var forcePriceUpdate bool
+
+var pricesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Refresh prices.db from the configured quote source",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := ledger.NewReader(cfg.Ledger.Binary, cfg.LedgerFile())
+		bals, err := r.Bal(sync.Accounts, "--average-lot-prices")
+		if err != nil {
+			return err
+		}
+
+		reg := price.NewRegistryFromConfig(cfg)
+		return reg.Update(context.Background(), cfg.PriceDB(), sync.CostBasisTickers(bals), time.Now(), forcePriceUpdate)
+	},
+}
+
+func init() {
+	pricesUpdateCmd.Flags().BoolVar(&forcePriceUpdate, "force", false, "update even outside market hours")
+	pricesCmd.AddCommand(pricesUpdateCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/adityaxdiwakar/helios/internal/reposync"
+	"github.com/spf13/cobra"
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Manage the local checkout of the ledger git repo",
+}
+
+var repoPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Clone or fast-forward the ledger repo checkout",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reposync.Pull(reposync.Options{
+			Dir:      cfg.RepoDir(),
+			URL:      cfg.Repo.URL,
+			Branch:   cfg.Repo.Branch,
+			Username: cfg.Repo.Username,
+			Token:    cfg.Repo.Token,
+		})
+	},
+}
+
+func init() {
+	repoCmd.AddCommand(repoPullCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/adityaxdiwakar/helios/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var reportPull bool
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Print current balances without pushing anything to InfluxDB",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := sync.Collect(cfg, sync.Options{PullRepo: reportPull, UpdatePrices: false})
+		if err != nil {
+			return err
+		}
+		printBalance(res.Ira)
+		printBalance(res.Tax)
+		for _, bal := range res.CostBasis {
+			printBalance(bal)
+		}
+		return nil
+	},
+}
+
+func printBalance(b sync.AccountBalance) {
+	fmt.Printf("%-10s basis=%.2f market=%.2f gain=%.2f\n", b.Name, b.Basis, b.Market, b.Gain)
+}
+
+func init() {
+	reportCmd.Flags().BoolVar(&reportPull, "pull", false, "pull the ledger repo before reporting")
+}
@@ -0,0 +1,168 @@
+// Package cmd wires up helios' Cobra command tree: sync, report, prices,
+// repo, margin, and pnl.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/config"
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cfgFile string
+	datadir string
+	cfg     config.Config
+
+	sentryEnabled bool
+
+	// flagLedgerBinary and the rest below let a CLI flag override the
+	// corresponding config-file value, the way --datadir already does.
+	// They default to "" (unset) so Load()'s config-file/env values pass
+	// through untouched when a flag isn't given.
+	flagLedgerBinary string
+	flagRepoURL      string
+	flagRepoBranch   string
+	flagRepoUsername string
+	flagRepoToken    string
+	flagInfluxURL    string
+	flagInfluxOrg    string
+	flagInfluxBucket string
+	flagInfluxToken  string
+	flagSentryDSN    string
+
+	flagCostBasisMethod string
+	flagBenchmarkSymbol string
+	flagEquitySource    string
+	flagCSVFile         string
+	flagFXCSVFile       string
+	flagSymbolClasses   map[string]string
+)
+
+// Execute runs the root command.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		bail(err, 1)
+	}
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "helios",
+	Short: "helios syncs a plain-text ledger to InfluxDB",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		path := cfgFile
+		if path == "" {
+			path = config.DefaultConfigPath()
+		}
+		loaded, err := config.Load(path)
+		if err != nil {
+			return err
+		}
+		if datadir != "" {
+			loaded.DataDir = datadir
+		}
+		if flagLedgerBinary != "" {
+			loaded.Ledger.Binary = flagLedgerBinary
+		}
+		if flagRepoURL != "" {
+			loaded.Repo.URL = flagRepoURL
+		}
+		if flagRepoBranch != "" {
+			loaded.Repo.Branch = flagRepoBranch
+		}
+		if flagRepoUsername != "" {
+			loaded.Repo.Username = flagRepoUsername
+		}
+		if flagRepoToken != "" {
+			loaded.Repo.Token = flagRepoToken
+		}
+		if flagInfluxURL != "" {
+			loaded.Influx.URL = flagInfluxURL
+		}
+		if flagInfluxOrg != "" {
+			loaded.Influx.Org = flagInfluxOrg
+		}
+		if flagInfluxBucket != "" {
+			loaded.Influx.Bucket = flagInfluxBucket
+		}
+		if flagInfluxToken != "" {
+			loaded.Influx.Token = flagInfluxToken
+		}
+		if flagSentryDSN != "" {
+			loaded.Sentry.DSN = flagSentryDSN
+		}
+		if flagCostBasisMethod != "" {
+			loaded.PnL.CostBasisMethod = flagCostBasisMethod
+		}
+		if flagBenchmarkSymbol != "" {
+			loaded.PnL.BenchmarkSymbol = flagBenchmarkSymbol
+		}
+		if flagEquitySource != "" {
+			loaded.Price.EquitySource = flagEquitySource
+		}
+		if flagCSVFile != "" {
+			loaded.Price.CSVFile = flagCSVFile
+		}
+		if flagFXCSVFile != "" {
+			loaded.Price.FXCSVFile = flagFXCSVFile
+		}
+		for symbol, class := range flagSymbolClasses {
+			if loaded.Price.SymbolClasses == nil {
+				loaded.Price.SymbolClasses = map[string]string{}
+			}
+			loaded.Price.SymbolClasses[symbol] = class
+		}
+		cfg = loaded
+
+		if cfg.Sentry.DSN != "" {
+			sentryEnabled = true
+			if err := sentry.Init(sentry.ClientOptions{
+				Dsn:              cfg.Sentry.DSN,
+				TracesSampleRate: 1.0,
+			}); err != nil {
+				return fmt.Errorf("sentry.Init: %w", err)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default $HELIOS_HOME/config.toml)")
+	rootCmd.PersistentFlags().StringVar(&datadir, "datadir", "", "override the data directory (records.ldg, prices.db, repo/, token)")
+	rootCmd.PersistentFlags().StringVar(&flagLedgerBinary, "ledger-binary", "", "override the ledger binary to invoke")
+	rootCmd.PersistentFlags().StringVar(&flagRepoURL, "repo-url", "", "override the ledger git repo URL")
+	rootCmd.PersistentFlags().StringVar(&flagRepoBranch, "repo-branch", "", "override the ledger git repo branch")
+	rootCmd.PersistentFlags().StringVar(&flagRepoUsername, "repo-username", "", "override the ledger git repo username")
+	rootCmd.PersistentFlags().StringVar(&flagRepoToken, "repo-token", "", "override the ledger git repo auth token")
+	rootCmd.PersistentFlags().StringVar(&flagInfluxURL, "influx-url", "", "override the InfluxDB URL")
+	rootCmd.PersistentFlags().StringVar(&flagInfluxOrg, "influx-org", "", "override the InfluxDB org")
+	rootCmd.PersistentFlags().StringVar(&flagInfluxBucket, "influx-bucket", "", "override the InfluxDB bucket")
+	rootCmd.PersistentFlags().StringVar(&flagInfluxToken, "influx-token", "", "override the InfluxDB auth token")
+	rootCmd.PersistentFlags().StringVar(&flagSentryDSN, "sentry-dsn", "", "override the Sentry DSN")
+	rootCmd.PersistentFlags().StringVar(&flagCostBasisMethod, "cost-basis-method", "", "override the PnL cost basis method (fifo or average)")
+	rootCmd.PersistentFlags().StringVar(&flagBenchmarkSymbol, "benchmark-symbol", "", "override the PnL buy-and-hold benchmark ticker")
+	rootCmd.PersistentFlags().StringVar(&flagEquitySource, "equity-source", "", "override the equity quote source (stooq, tda, or csv)")
+	rootCmd.PersistentFlags().StringVar(&flagCSVFile, "csv-file", "", "override the csv equity source's price file")
+	rootCmd.PersistentFlags().StringVar(&flagFXCSVFile, "fx-csv-file", "", "override the fx source's price file")
+	rootCmd.PersistentFlags().StringToStringVar(&flagSymbolClasses, "symbol-class", nil, "override a symbol's asset class, e.g. --symbol-class BTC=crypto (repeatable)")
+
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(pricesCmd)
+	rootCmd.AddCommand(repoCmd)
+}
+
+// bail reports err to Sentry (if configured), prints it, and exits with
+// code. It mirrors helios' historical fatal-error behavior.
+func bail(err error, code int) {
+	if sentryEnabled {
+		sentry.CaptureMessage(err.Error())
+		sentry.Flush(2 * time.Second)
+	}
+	fmt.Println(err)
+	os.Exit(code)
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/adityaxdiwakar/helios/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Pull the ledger repo, refresh prices, and push balances to InfluxDB",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sync.RunWithState(cfg)
+	},
+}
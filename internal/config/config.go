@@ -0,0 +1,197 @@
+// Package config loads helios' configuration from a TOML file, environment
+// variables, and CLI flags, in that order of increasing precedence.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds every value helios needs to run a sync/report cycle. Zero
+// values are filled in by Default() before a config file is read.
+type Config struct {
+	DataDir string `toml:"datadir"`
+
+	Ledger LedgerConfig `toml:"ledger"`
+	Repo   RepoConfig   `toml:"repo"`
+	Influx InfluxConfig `toml:"influx"`
+	Sentry SentryConfig `toml:"sentry"`
+	PnL    PnLConfig    `toml:"pnl"`
+	Price  PriceConfig  `toml:"price"`
+}
+
+// PriceConfig controls which quote source serves each asset class.
+type PriceConfig struct {
+	// EquitySource selects the quote source for the "equity" class: "stooq"
+	// (default), "tda", or "csv".
+	EquitySource string `toml:"equity_source"`
+	// CSVFile is the path read by the "csv" equity source.
+	CSVFile string `toml:"csv_file"`
+	// FXCSVFile is the path read by the "fx" class's quote source. FX has
+	// no live venue wired up yet, so it's always CSV-backed; leave empty
+	// to leave the "fx" class unconfigured.
+	FXCSVFile string `toml:"fx_csv_file"`
+	// SymbolClasses maps a ticker to "equity", "crypto", or "fx". A symbol
+	// absent from this map defaults to "equity".
+	SymbolClasses map[string]string `toml:"symbol_classes"`
+}
+
+type PnLConfig struct {
+	// CostBasisMethod is "fifo" or "average". Defaults to "fifo".
+	CostBasisMethod string `toml:"cost_basis_method"`
+	// BenchmarkSymbol is the buy-and-hold comparison ticker, e.g. "SPY".
+	BenchmarkSymbol string `toml:"benchmark_symbol"`
+}
+
+type LedgerConfig struct {
+	Binary string `toml:"binary"`
+}
+
+type RepoConfig struct {
+	URL      string `toml:"url"`
+	Branch   string `toml:"branch"`
+	Username string `toml:"username"`
+	Token    string `toml:"token"`
+}
+
+type InfluxConfig struct {
+	URL    string `toml:"url"`
+	Org    string `toml:"org"`
+	Bucket string `toml:"bucket"`
+	Token  string `toml:"token"`
+}
+
+type SentryConfig struct {
+	DSN string `toml:"dsn"`
+}
+
+// Default returns a Config populated with helios' historical hardcoded
+// ledger binary, repo, and InfluxDB values. The data directory defaults to
+// defaultDataDir() and equity quotes default to Stooq, so a user who
+// supplies no config file does not get the old working-directory paths or
+// the tdaLedgerUpdate quote source.
+func Default() Config {
+	return Config{
+		DataDir: defaultDataDir(),
+		Ledger: LedgerConfig{
+			Binary: "ledger",
+		},
+		Repo: RepoConfig{
+			URL:    "https://github.com/adityaxdiwakar/accounting",
+			Branch: "master",
+		},
+		Influx: InfluxConfig{
+			URL:    "http://localhost:8086",
+			Org:    "primary",
+			Bucket: "primary",
+		},
+		PnL: PnLConfig{
+			CostBasisMethod: "fifo",
+			BenchmarkSymbol: "SPY",
+		},
+		Price: PriceConfig{
+			EquitySource: "stooq",
+		},
+	}
+}
+
+// defaultDataDir returns $HELIOS_HOME if set, otherwise
+// $XDG_DATA_HOME/helios, falling back to ~/.local/share/helios.
+func defaultDataDir() string {
+	if home := os.Getenv("HELIOS_HOME"); home != "" {
+		return home
+	}
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, "helios")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "helios-data"
+	}
+	return filepath.Join(home, ".local", "share", "helios")
+}
+
+// DefaultConfigPath returns the location helios looks for a config file when
+// none is given on the command line: $HELIOS_HOME/config.toml.
+func DefaultConfigPath() string {
+	return filepath.Join(defaultDataDir(), "config.toml")
+}
+
+// Load reads path (if it exists) on top of Default(), then applies
+// environment overrides. A missing file is not an error, since a brand new
+// datadir and CLI-supplied credentials are enough to run.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if _, err := os.Stat(path); err == nil {
+			if _, err := toml.DecodeFile(path, &cfg); err != nil {
+				return Config{}, fmt.Errorf("decode config %s: %w", path, err)
+			}
+		} else if !os.IsNotExist(err) {
+			return Config{}, fmt.Errorf("stat config %s: %w", path, err)
+		}
+	}
+
+	applyEnv(&cfg)
+	return cfg, nil
+}
+
+// applyEnv overrides cfg with any HELIOS_* environment variables present,
+// ranking above the config file but below explicit CLI flags.
+func applyEnv(cfg *Config) {
+	if v := os.Getenv("HELIOS_DATADIR"); v != "" {
+		cfg.DataDir = v
+	}
+	if v := os.Getenv("HELIOS_LEDGER_BINARY"); v != "" {
+		cfg.Ledger.Binary = v
+	}
+	if v := os.Getenv("HELIOS_REPO_URL"); v != "" {
+		cfg.Repo.URL = v
+	}
+	if v := os.Getenv("HELIOS_REPO_USERNAME"); v != "" {
+		cfg.Repo.Username = v
+	}
+	if v := os.Getenv("HELIOS_REPO_TOKEN"); v != "" {
+		cfg.Repo.Token = v
+	}
+	if v := os.Getenv("HELIOS_INFLUX_URL"); v != "" {
+		cfg.Influx.URL = v
+	}
+	if v := os.Getenv("HELIOS_INFLUX_TOKEN"); v != "" {
+		cfg.Influx.Token = v
+	}
+	if v := os.Getenv("HELIOS_SENTRY_DSN"); v != "" {
+		cfg.Sentry.DSN = v
+	}
+}
+
+// LedgerFile returns the path to the ledger journal inside the repo
+// checkout, e.g. <datadir>/repo/records.ldg.
+func (c Config) LedgerFile() string {
+	return filepath.Join(c.RepoDir(), "records.ldg")
+}
+
+// RepoDir returns the path to the git checkout of the ledger repo.
+func (c Config) RepoDir() string {
+	return filepath.Join(c.DataDir, "repo")
+}
+
+// PriceDB returns the path to the ledger price-db file.
+func (c Config) PriceDB() string {
+	return filepath.Join(c.DataDir, "prices.db")
+}
+
+// TokenFile returns the path to the auth token file some price sources
+// (e.g. tdaLedgerUpdate) expect on disk.
+func (c Config) TokenFile() string {
+	return filepath.Join(c.DataDir, "token")
+}
+
+// StateFile returns the path to helios' persisted run-state database.
+func (c Config) StateFile() string {
+	return filepath.Join(c.DataDir, "state.db")
+}
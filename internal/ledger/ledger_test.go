@@ -0,0 +1,106 @@
+package ledger
+
+import (
+	"os"
+	"testing"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("ReadFile(%s): %v", name, err)
+	}
+	return data
+}
+
+func TestParsePostingsSimple(t *testing.T) {
+	postings, err := parsePostings(readTestdata(t, "register_simple.txt"))
+	if err != nil {
+		t.Fatalf("parsePostings: %v", err)
+	}
+	if len(postings) != 2 {
+		t.Fatalf("len(postings) = %d, want 2", len(postings))
+	}
+	if postings[0].Account != "Assets:Checking" || postings[0].Commodity != "$" {
+		t.Errorf("unexpected posting: %+v", postings[0])
+	}
+	if got, want := postings[1].Quantity, -1800.00; got != want {
+		t.Errorf("quantity = %v, want %v", got, want)
+	}
+	if postings[0].LotPrice != 0 {
+		t.Errorf("LotPrice = %v, want 0 for an unannotated amount", postings[0].LotPrice)
+	}
+}
+
+func TestParsePostingsLotPriced(t *testing.T) {
+	postings, err := parsePostings(readTestdata(t, "register_lot_priced.txt"))
+	if err != nil {
+		t.Fatalf("parsePostings: %v", err)
+	}
+	if len(postings) != 2 {
+		t.Fatalf("len(postings) = %d, want 2", len(postings))
+	}
+	if postings[0].Quantity != 10 || postings[1].Quantity != -10 {
+		t.Errorf("unexpected quantities: %+v", postings)
+	}
+	if postings[0].LotPrice != 150.00 {
+		t.Errorf("LotPrice = %v, want 150.00", postings[0].LotPrice)
+	}
+	if postings[0].LotDate.Format("2006-01-02") != "2023-01-05" {
+		t.Errorf("LotDate = %v, want 2023-01-05", postings[0].LotDate)
+	}
+}
+
+func TestAggregateBalancesSingleCommodity(t *testing.T) {
+	balances := aggregateBalances([]Posting{
+		{Account: "Assets:Checking", Commodity: "$", Quantity: 10000.00},
+		{Account: "Assets:Checking", Commodity: "$", Quantity: 2345.67},
+	})
+	if len(balances) != 1 || len(balances[0].Amounts) != 1 {
+		t.Fatalf("unexpected balances: %+v", balances)
+	}
+	if got, want := balances[0].Amounts[0].Quantity, 12345.67; got != want {
+		t.Errorf("quantity = %v, want %v", got, want)
+	}
+	if got, want := Total(balances), 12345.67; got != want {
+		t.Errorf("Total = %v, want %v", got, want)
+	}
+}
+
+func TestAggregateBalancesMultiCommodity(t *testing.T) {
+	balances := aggregateBalances([]Posting{
+		{Account: "Assets:Brokerage", Commodity: "$", Quantity: 500.00},
+		{Account: "Assets:Brokerage", Commodity: "AAPL", Quantity: 10},
+	})
+	if len(balances) != 1 || len(balances[0].Amounts) != 2 {
+		t.Fatalf("unexpected balances: %+v", balances)
+	}
+	if balances[0].Amounts[0].Commodity != "$" || balances[0].Amounts[1].Commodity != "AAPL" {
+		t.Errorf("unexpected commodities: %+v", balances[0].Amounts)
+	}
+}
+
+func TestAggregateBalancesNegative(t *testing.T) {
+	balances := aggregateBalances([]Posting{
+		{Account: "Liabilities:Margin:IBKR", Commodity: "$", Quantity: -5000.00},
+		{Account: "Liabilities:Margin:IBKR", Commodity: "$", Quantity: 2000.00},
+	})
+	if got, want := Total(balances), -3000.00; got != want {
+		t.Errorf("Total = %v, want %v", got, want)
+	}
+}
+
+func TestAggregateBalancesLotPricedIsQuantityWeightedAverage(t *testing.T) {
+	balances := aggregateBalances([]Posting{
+		{Account: "Allocation:Equities:AAPL", Commodity: "AAPL", Quantity: 10, LotPrice: 150.00},
+		{Account: "Allocation:Equities:AAPL", Commodity: "AAPL", Quantity: 10, LotPrice: 170.00},
+	})
+	amt := balances[0].Amounts[0]
+	if amt.Cost == nil {
+		t.Fatal("expected Cost to be set")
+	}
+	if got, want := *amt.Cost, 160.00; got != want {
+		t.Errorf("cost = %v, want %v", got, want)
+	}
+}
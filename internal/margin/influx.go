@@ -0,0 +1,79 @@
+package margin
+
+import (
+	"sort"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// Points converts h into Influx points under the "margin" measurement,
+// tagged by asset and type=loan|repay|interest, plus one "margin_accrued"
+// point per entry of AccruedInterestSeries, tagged by asset.
+func (h *History) Points() []*write.Point {
+	var points []*write.Point
+
+	for _, r := range h.Loans {
+		points = append(points, influxdb2.NewPointWithMeasurement("margin").
+			AddTag("asset", r.Asset).
+			AddTag("type", "loan").
+			AddTag("txid", r.TxID).
+			AddField("principal", r.Principal).
+			SetTime(r.Timestamp))
+	}
+	for _, r := range h.Repays {
+		points = append(points, influxdb2.NewPointWithMeasurement("margin").
+			AddTag("asset", r.Asset).
+			AddTag("type", "repay").
+			AddTag("txid", r.TxID).
+			AddField("principal", r.Principal).
+			SetTime(r.Timestamp))
+	}
+	for _, r := range h.Interest {
+		points = append(points, influxdb2.NewPointWithMeasurement("margin").
+			AddTag("asset", r.Asset).
+			AddTag("type", "interest").
+			AddTag("txid", r.TxID).
+			AddField("amount", r.Amount).
+			AddField("rate", r.Rate).
+			SetTime(r.Timestamp))
+	}
+
+	for _, acc := range h.AccruedInterestSeries() {
+		points = append(points, influxdb2.NewPointWithMeasurement("margin_accrued").
+			AddTag("asset", acc.Asset).
+			AddField("accrued", acc.Accrued).
+			SetTime(acc.Date))
+	}
+
+	return points
+}
+
+// DailyAccrual is the running total of margin interest paid for an asset as
+// of a given day.
+type DailyAccrual struct {
+	Asset   string
+	Date    time.Time
+	Accrued float64
+}
+
+// AccruedInterestSeries turns the interest records in h into a running,
+// per-asset cost-of-leverage series suitable for a Grafana chart.
+func (h *History) AccruedInterestSeries() []DailyAccrual {
+	interest := make([]MarginInterest, len(h.Interest))
+	copy(interest, h.Interest)
+	sort.Slice(interest, func(i, j int) bool { return interest[i].Timestamp.Before(interest[j].Timestamp) })
+
+	running := map[string]float64{}
+	var series []DailyAccrual
+	for _, r := range interest {
+		running[r.Asset] += r.Amount
+		series = append(series, DailyAccrual{
+			Asset:   r.Asset,
+			Date:    r.Timestamp,
+			Accrued: running[r.Asset],
+		})
+	}
+	return series
+}
@@ -0,0 +1,166 @@
+// Package margin recognizes margin-loan, margin-repay, and margin-interest
+// postings in the ledger and turns them into per-asset records, similar to
+// bbgo's MarginLoanRecord/MarginRepayRecord/MarginInterest types.
+package margin
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+)
+
+const (
+	// LoanAccountPrefix holds the outstanding margin principal per asset.
+	// A negative posting increases the loan, a positive one repays it.
+	LoanAccountPrefix = "Liabilities:Margin:"
+	// InterestAccountPrefix holds accrued margin interest expense per asset.
+	InterestAccountPrefix = "Expenses:Interest:Margin:"
+)
+
+// MarginLoanRecord is a single draw against a margin line.
+type MarginLoanRecord struct {
+	TxID      string
+	Asset     string
+	Principal float64
+	Timestamp time.Time
+}
+
+// MarginRepayRecord is a single repayment of margin principal.
+type MarginRepayRecord struct {
+	TxID      string
+	Asset     string
+	Principal float64
+	Timestamp time.Time
+}
+
+// MarginInterest is a single accrued-interest charge against an asset's
+// margin balance.
+type MarginInterest struct {
+	TxID   string
+	Asset  string
+	Amount float64
+	// Rate is the periodic interest rate this charge represents: Amount
+	// divided by the outstanding margin principal for Asset immediately
+	// before the charge. It has no fixed interval (daily, monthly, ...) —
+	// that's set by however often the broker posts interest — so multiply
+	// by the accrual frequency to annualize it.
+	Rate      float64
+	Timestamp time.Time
+}
+
+// History is every margin-related record observed since a given time.
+type History struct {
+	Loans    []MarginLoanRecord
+	Repays   []MarginRepayRecord
+	Interest []MarginInterest
+}
+
+// GetMarginHistory walks the ledger via `ledger reg` and returns every
+// margin loan, repay, and interest posting since the given time.
+func GetMarginHistory(r ledger.Reader, since time.Time) (*History, error) {
+	loanPostings, err := r.Reg("--begin", since.Format("2006-01-02"), LoanAccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("query margin postings: %w", err)
+	}
+	interestPostings, err := r.Reg("--begin", since.Format("2006-01-02"), InterestAccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("query margin interest postings: %w", err)
+	}
+
+	h := &History{}
+	for _, p := range loanPostings {
+		asset := strings.TrimPrefix(p.Account, LoanAccountPrefix)
+		txID := postingID(p)
+		if p.Quantity < 0 {
+			h.Loans = append(h.Loans, MarginLoanRecord{
+				TxID:      txID,
+				Asset:     asset,
+				Principal: -p.Quantity,
+				Timestamp: p.Date,
+			})
+		} else {
+			h.Repays = append(h.Repays, MarginRepayRecord{
+				TxID:      txID,
+				Asset:     asset,
+				Principal: p.Quantity,
+				Timestamp: p.Date,
+			})
+		}
+	}
+
+	principal := principalSeries(loanPostings)
+	for _, p := range interestPostings {
+		asset := strings.TrimPrefix(p.Account, InterestAccountPrefix)
+		var rate float64
+		if balance := balanceAt(principal[asset], p.Date); balance > 0 {
+			rate = p.Quantity / balance
+		}
+		h.Interest = append(h.Interest, MarginInterest{
+			TxID:      postingID(p),
+			Asset:     asset,
+			Amount:    p.Quantity,
+			Rate:      rate,
+			Timestamp: p.Date,
+		})
+	}
+
+	return h, nil
+}
+
+// balancePoint is the outstanding margin principal for one asset as of
+// Time, immediately after a loan or repay posting.
+type balancePoint struct {
+	Time    time.Time
+	Balance float64
+}
+
+// principalSeries turns loanPostings into a per-asset, time-ordered running
+// balance, so an interest posting can be matched against the principal it
+// accrued against.
+func principalSeries(loanPostings []ledger.Posting) map[string][]balancePoint {
+	byAsset := map[string][]ledger.Posting{}
+	for _, p := range loanPostings {
+		asset := strings.TrimPrefix(p.Account, LoanAccountPrefix)
+		byAsset[asset] = append(byAsset[asset], p)
+	}
+
+	series := map[string][]balancePoint{}
+	for asset, postings := range byAsset {
+		sort.Slice(postings, func(i, j int) bool { return postings[i].Date.Before(postings[j].Date) })
+
+		var running float64
+		points := make([]balancePoint, 0, len(postings))
+		for _, p := range postings {
+			running -= p.Quantity // a loan posting is negative, a repay positive
+			points = append(points, balancePoint{Time: p.Date, Balance: running})
+		}
+		series[asset] = points
+	}
+	return series
+}
+
+// balanceAt returns the last balance recorded at or before at, in a series
+// already sorted by Time ascending.
+func balanceAt(points []balancePoint, at time.Time) float64 {
+	var balance float64
+	for _, p := range points {
+		if p.Time.After(at) {
+			break
+		}
+		balance = p.Balance
+	}
+	return balance
+}
+
+// postingID derives a stable transaction id for a posting. The ledger
+// format has no native transaction id, so one is hashed from the fields
+// that make a posting unique within a reg query.
+func postingID(p ledger.Posting) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%.8f", p.Date.Format("2006-01-02"), p.Account, p.Quantity)))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,38 @@
+package pnl
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/price"
+)
+
+// SimulateBenchmark replays trades' net cash-flow timeline as if every
+// dollar invested/withdrawn had instead bought or sold symbol, and returns
+// the resulting position's current market value.
+//
+// Each buy trade is treated as cash leaving the portfolio to purchase
+// symbol at that day's price; each sell is treated as cash returning,
+// funded by selling symbol at that day's price. This approximates "what if
+// I'd just bought the index with the same money, on the same days."
+func SimulateBenchmark(trades []Trade, prices []price.PricePoint, symbol string, now time.Time) (float64, bool) {
+	sorted := make([]Trade, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	var shares float64
+	for _, t := range sorted {
+		cash := t.Quantity * t.Price
+		p, ok := price.PriceAt(prices, symbol, t.Date)
+		if !ok || p <= 0 {
+			continue
+		}
+		shares += cash / p
+	}
+
+	current, ok := price.PriceAt(prices, symbol, now)
+	if !ok {
+		return 0, false
+	}
+	return shares * current, true
+}
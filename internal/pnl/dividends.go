@@ -0,0 +1,29 @@
+package pnl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+)
+
+// DividendAccountPrefix holds dividend income postings, one sub-account per
+// ticker (e.g. Income:Dividends:AAPL).
+const DividendAccountPrefix = "Income:Dividends:"
+
+// GetDividends sums dividend income postings since `since`, keyed by
+// ticker. Ledger records income as a negative amount, so the sign is
+// flipped to report a positive dollar figure.
+func GetDividends(r ledger.Reader, since time.Time) (map[string]float64, error) {
+	postings, err := r.Reg("--begin", since.Format("2006-01-02"), DividendAccountPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	dividends := map[string]float64{}
+	for _, p := range postings {
+		ticker := strings.TrimPrefix(p.Account, DividendAccountPrefix)
+		dividends[ticker] += -p.Quantity
+	}
+	return dividends, nil
+}
@@ -0,0 +1,32 @@
+package pnl
+
+import (
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// Points converts results into "pnl" measurement points tagged by account
+// and ticker.
+func Points(account string, results []Result) []*write.Point {
+	points := make([]*write.Point, 0, len(results))
+	for _, r := range results {
+		points = append(points, influxdb2.NewPointWithMeasurement("pnl").
+			AddTag("account", account).
+			AddTag("ticker", r.Ticker).
+			AddField("realized", r.Realized).
+			AddField("unrealized", r.Unrealized).
+			AddField("realized_ytd", r.RealizedYTD).
+			AddField("dividends", r.Dividends))
+	}
+	return points
+}
+
+// BenchmarkPoint builds a single "pnl" point carrying the benchmark_market
+// field, tagged the same way as the account's own PnL points so Grafana can
+// overlay them.
+func BenchmarkPoint(account, benchmarkSymbol string, marketValue float64) *write.Point {
+	return influxdb2.NewPointWithMeasurement("pnl").
+		AddTag("account", account).
+		AddTag("ticker", benchmarkSymbol).
+		AddField("benchmark_market", marketValue)
+}
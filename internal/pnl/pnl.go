@@ -0,0 +1,167 @@
+// Package pnl computes realized and unrealized profit/loss per ticker from
+// the ledger's trade history, plus a buy-and-hold benchmark comparison.
+package pnl
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	"github.com/adityaxdiwakar/helios/internal/price"
+)
+
+// Method selects how sells are matched against open lots.
+type Method string
+
+const (
+	FIFO    Method = "fifo"
+	Average Method = "average"
+)
+
+// Trade is a single buy or sell posting against a lot-priced account.
+// Quantity is positive for a buy, negative for a sell.
+type Trade struct {
+	Date     time.Time
+	Ticker   string
+	Quantity float64
+	Price    float64
+}
+
+// GetTrades runs `ledger reg --lot-dates --lot-prices <account>` and
+// converts the result into a time-ordered trade list, one per posting.
+func GetTrades(r ledger.Reader, account string) ([]Trade, error) {
+	postings, err := r.Reg("--lot-dates", "--lot-prices", account)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]Trade, len(postings))
+	for i, p := range postings {
+		trades[i] = Trade{Date: p.Date, Ticker: p.Commodity, Quantity: p.Quantity, Price: p.LotPrice}
+	}
+	return trades, nil
+}
+
+// openLot is a remaining, unmatched buy.
+type openLot struct {
+	Quantity float64
+	Price    float64
+}
+
+// Result is the computed PnL for one ticker.
+type Result struct {
+	Ticker      string
+	Realized    float64
+	Unrealized  float64
+	RealizedYTD float64
+	Dividends   float64
+}
+
+// Compute replays trades in date order, matching sells against open lots
+// per method, and values any remaining open lots using prices.
+func Compute(trades []Trade, dividends map[string]float64, prices []price.PricePoint, method Method, now time.Time) []Result {
+	lotsByTicker := map[string][]openLot{}
+	realized := map[string]float64{}
+	realizedYTD := map[string]float64{}
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+
+	sorted := make([]Trade, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	for _, t := range sorted {
+		lots := lotsByTicker[t.Ticker]
+		if t.Quantity >= 0 {
+			lots = append(lots, openLot{Quantity: t.Quantity, Price: t.Price})
+			lotsByTicker[t.Ticker] = lots
+			continue
+		}
+
+		toSell := -t.Quantity
+		var gain float64
+		switch method {
+		case Average:
+			gain, lots = matchAverage(lots, toSell, t.Price)
+		default:
+			gain, lots = matchFIFO(lots, toSell, t.Price)
+		}
+		lotsByTicker[t.Ticker] = lots
+		realized[t.Ticker] += gain
+		if !t.Date.Before(yearStart) {
+			realizedYTD[t.Ticker] += gain
+		}
+	}
+
+	tickers := map[string]bool{}
+	for t := range lotsByTicker {
+		tickers[t] = true
+	}
+	for t := range realized {
+		tickers[t] = true
+	}
+	for t := range dividends {
+		tickers[t] = true
+	}
+
+	var results []Result
+	for ticker := range tickers {
+		unrealized := 0.0
+		if p, ok := price.PriceAt(prices, ticker, now); ok {
+			for _, lot := range lotsByTicker[ticker] {
+				unrealized += (p - lot.Price) * lot.Quantity
+			}
+		}
+		results = append(results, Result{
+			Ticker:      ticker,
+			Realized:    realized[ticker],
+			Unrealized:  unrealized,
+			RealizedYTD: realizedYTD[ticker],
+			Dividends:   dividends[ticker],
+		})
+	}
+	return results
+}
+
+// matchFIFO consumes the oldest open lots first.
+func matchFIFO(lots []openLot, toSell, sellPrice float64) (float64, []openLot) {
+	var gain float64
+	for len(lots) > 0 && toSell > 0 {
+		lot := lots[0]
+		qty := lot.Quantity
+		if qty > toSell {
+			qty = toSell
+		}
+		gain += (sellPrice - lot.Price) * qty
+		lot.Quantity -= qty
+		toSell -= qty
+		if lot.Quantity <= 0 {
+			lots = lots[1:]
+		} else {
+			lots[0] = lot
+		}
+	}
+	return gain, lots
+}
+
+// matchAverage treats all open lots for a ticker as a single pool priced at
+// their quantity-weighted average cost.
+func matchAverage(lots []openLot, toSell, sellPrice float64) (float64, []openLot) {
+	var totalQty, totalCost float64
+	for _, lot := range lots {
+		totalQty += lot.Quantity
+		totalCost += lot.Quantity * lot.Price
+	}
+	if totalQty <= 0 {
+		return 0, lots
+	}
+	avgPrice := totalCost / totalQty
+	if toSell > totalQty {
+		toSell = totalQty
+	}
+	gain := (sellPrice - avgPrice) * toSell
+	remaining := totalQty - toSell
+	if remaining <= 0 {
+		return gain, nil
+	}
+	return gain, []openLot{{Quantity: remaining, Price: avgPrice}}
+}
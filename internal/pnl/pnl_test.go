@@ -0,0 +1,84 @@
+package pnl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/price"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestComputeFIFORealizesOldestLotFirst(t *testing.T) {
+	trades := []Trade{
+		{Date: date("2023-01-01"), Ticker: "AAPL", Quantity: 10, Price: 100},
+		{Date: date("2023-02-01"), Ticker: "AAPL", Quantity: 10, Price: 120},
+		{Date: date("2023-03-01"), Ticker: "AAPL", Quantity: -10, Price: 150},
+	}
+
+	results := Compute(trades, nil, nil, FIFO, date("2023-06-01"))
+	if len(results) != 1 {
+		t.Fatalf("len(results) = %d, want 1", len(results))
+	}
+	if got, want := results[0].Realized, 500.0; got != want {
+		t.Errorf("Realized = %.2f, want %.2f", got, want)
+	}
+}
+
+func TestComputeAveragePoolsOpenLots(t *testing.T) {
+	trades := []Trade{
+		{Date: date("2023-01-01"), Ticker: "AAPL", Quantity: 10, Price: 100},
+		{Date: date("2023-02-01"), Ticker: "AAPL", Quantity: 10, Price: 120},
+		{Date: date("2023-03-01"), Ticker: "AAPL", Quantity: -10, Price: 150},
+	}
+
+	results := Compute(trades, nil, nil, Average, date("2023-06-01"))
+	if len(results) != 1 {
+		t.Fatalf("len(results) = %d, want 1", len(results))
+	}
+	if got, want := results[0].Realized, 400.0; got != want {
+		t.Errorf("Realized = %.2f, want %.2f", got, want)
+	}
+}
+
+func TestComputeUnrealizedUsesCurrentPrice(t *testing.T) {
+	trades := []Trade{
+		{Date: date("2023-01-01"), Ticker: "AAPL", Quantity: 10, Price: 100},
+	}
+	prices := []price.PricePoint{
+		{Symbol: "AAPL", Time: date("2023-06-01"), Price: 130},
+	}
+
+	results := Compute(trades, nil, prices, FIFO, date("2023-06-01"))
+	if len(results) != 1 {
+		t.Fatalf("len(results) = %d, want 1", len(results))
+	}
+	if got, want := results[0].Unrealized, 300.0; got != want {
+		t.Errorf("Unrealized = %.2f, want %.2f", got, want)
+	}
+}
+
+func TestSimulateBenchmark(t *testing.T) {
+	trades := []Trade{
+		{Date: date("2023-01-01"), Ticker: "AAPL", Quantity: 10, Price: 100},
+	}
+	prices := []price.PricePoint{
+		{Symbol: "SPY", Time: date("2023-01-01"), Price: 400},
+		{Symbol: "SPY", Time: date("2023-06-01"), Price: 440},
+	}
+
+	market, ok := SimulateBenchmark(trades, prices, "SPY", date("2023-06-01"))
+	if !ok {
+		t.Fatal("SimulateBenchmark returned ok=false")
+	}
+	want := (1000.0 / 400.0) * 440.0
+	if market != want {
+		t.Errorf("market = %.4f, want %.4f", market, want)
+	}
+}
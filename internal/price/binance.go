@@ -0,0 +1,73 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BinanceSource fetches spot prices from Binance's public ticker endpoint,
+// which needs no API key or authentication.
+type BinanceSource struct {
+	HTTPClient *http.Client
+	// QuoteCurrency is appended to a symbol to form the Binance trading
+	// pair, e.g. "USDT" turns "BTC" into "BTCUSDT". Defaults to "USDT".
+	QuoteCurrency string
+}
+
+func (s BinanceSource) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (s BinanceSource) pair(symbol string) string {
+	quote := s.QuoteCurrency
+	if quote == "" {
+		quote = "USDT"
+	}
+	return strings.ToUpper(symbol) + quote
+}
+
+// Quote fetches symbol's latest traded price. Binance's ticker endpoint
+// doesn't report a trade timestamp, so asOf is the time the request
+// completed.
+func (s BinanceSource) Quote(ctx context.Context, symbol string) (float64, time.Time, error) {
+	url := fmt.Sprintf("https://api.binance.com/api/v3/ticker/price?symbol=%s", s.pair(symbol))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("binance quote %s: %w", symbol, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, time.Time{}, fmt.Errorf("binance quote %s: unexpected status %s", symbol, resp.Status)
+	}
+
+	var payload struct {
+		Price string `json:"price"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return 0, time.Time{}, fmt.Errorf("binance quote %s: %w", symbol, err)
+	}
+
+	price, err := strconv.ParseFloat(payload.Price, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("binance quote %s: parse price %q: %w", symbol, payload.Price, err)
+	}
+	return price, time.Now(), nil
+}
+
+// SessionHours reports a 24/7 session, since crypto venues never close.
+func (s BinanceSource) SessionHours(symbol string) (open, close time.Time, tz *time.Location) {
+	return alwaysOpenSessionHours()
+}
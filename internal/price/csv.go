@@ -0,0 +1,61 @@
+package price
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// CSVSource reads quotes out of a user-maintained CSV file with rows of
+// "symbol,price,timestamp" (RFC3339). It's meant for symbols no other
+// source covers, e.g. manually priced or illiquid holdings.
+type CSVSource struct {
+	Path string
+}
+
+// Quote returns symbol's most recent row in Path.
+func (s CSVSource) Quote(ctx context.Context, symbol string) (float64, time.Time, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("parse csv price file %s: %w", s.Path, err)
+	}
+
+	var best float64
+	var bestTime time.Time
+	found := false
+	for _, row := range records {
+		if len(row) < 3 || row[0] != symbol {
+			continue
+		}
+		price, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("parse csv price %q: %w", row[1], err)
+		}
+		ts, err := time.Parse(time.RFC3339, row[2])
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("parse csv timestamp %q: %w", row[2], err)
+		}
+		if !found || ts.After(bestTime) {
+			best, bestTime, found = price, ts, true
+		}
+	}
+	if !found {
+		return 0, time.Time{}, fmt.Errorf("no quote for %s in %s", symbol, s.Path)
+	}
+	return best, bestTime, nil
+}
+
+// SessionHours treats a CSV source as always open, since it has no venue of
+// its own to be closed.
+func (s CSVSource) SessionHours(symbol string) (open, close time.Time, tz *time.Location) {
+	return alwaysOpenSessionHours()
+}
@@ -0,0 +1,63 @@
+package price
+
+import "time"
+
+// nyseHoliday reports whether date (interpreted in America/New_York) is a
+// full NYSE market holiday. It covers the standard fixed and
+// floating-weekday holidays; it does not account for Good Friday, which
+// NYSE observes but which has no fixed or nth-weekday rule.
+func nyseHoliday(date time.Time) bool {
+	y, m, d := date.Date()
+	switch {
+	case m == time.January && d == observedFixed(y, time.January, 1):
+		return true
+	case m == time.January && d == nthWeekday(y, time.January, time.Monday, 3):
+		return true // Martin Luther King Jr. Day
+	case m == time.February && d == nthWeekday(y, time.February, time.Monday, 3):
+		return true // Washington's Birthday
+	case m == time.May && d == lastWeekday(y, time.May, time.Monday):
+		return true // Memorial Day
+	case m == time.June && d == observedFixed(y, time.June, 19):
+		return true // Juneteenth
+	case m == time.July && d == observedFixed(y, time.July, 4):
+		return true // Independence Day
+	case m == time.September && d == nthWeekday(y, time.September, time.Monday, 1):
+		return true // Labor Day
+	case m == time.November && d == nthWeekday(y, time.November, time.Thursday, 4):
+		return true // Thanksgiving
+	case m == time.December && d == observedFixed(y, time.December, 25):
+		return true // Christmas
+	}
+	return false
+}
+
+// observedFixed returns the day-of-month a fixed holiday is observed on: if
+// it falls on a Saturday it's observed the preceding Friday, if a Sunday
+// the following Monday.
+func observedFixed(year int, month time.Month, day int) int {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	switch d.Weekday() {
+	case time.Saturday:
+		return day - 1
+	case time.Sunday:
+		return day + 1
+	default:
+		return day
+	}
+}
+
+// nthWeekday returns the day-of-month of the nth occurrence of weekday in
+// month.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) int {
+	d := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(weekday) - int(d.Weekday()) + 7) % 7
+	return 1 + offset + (n-1)*7
+}
+
+// lastWeekday returns the day-of-month of the last occurrence of weekday in
+// month.
+func lastWeekday(year int, month time.Month, weekday time.Weekday) int {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.Day() - offset
+}
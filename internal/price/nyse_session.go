@@ -0,0 +1,34 @@
+package price
+
+import "time"
+
+var nyseLocation = func() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}()
+
+// nyseSessionHours returns today's regular NYSE trading session
+// (9:30am-4:00pm America/New_York), or a zero-width window on weekends and
+// market holidays so IsOpen always reports closed.
+func nyseSessionHours() (open, close time.Time, tz *time.Location) {
+	now := time.Now().In(nyseLocation)
+	y, m, d := now.Date()
+
+	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday || nyseHoliday(now) {
+		midnight := time.Date(y, m, d, 0, 0, 0, 0, nyseLocation)
+		return midnight, midnight, nyseLocation
+	}
+
+	open = time.Date(y, m, d, 9, 30, 0, 0, nyseLocation)
+	close = time.Date(y, m, d, 16, 0, 0, 0, nyseLocation)
+	return open, close, nyseLocation
+}
+
+// alwaysOpenSessionHours is used by venues that trade continuously.
+func alwaysOpenSessionHours() (open, close time.Time, tz *time.Location) {
+	now := time.Now().UTC()
+	return now.AddDate(-1, 0, 0), now.AddDate(1, 0, 0), time.UTC
+}
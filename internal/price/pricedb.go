@@ -0,0 +1,115 @@
+package price
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PricePoint is one dated quote, as recorded in a ledger price-db file
+// ("P 2023-01-05 00:00:00 AAPL $150.00").
+type PricePoint struct {
+	Symbol string
+	Time   time.Time
+	Price  float64
+}
+
+// ReadPriceDB parses a ledger price-db file into a flat, time-ordered slice
+// of PricePoint.
+func ReadPriceDB(path string) ([]PricePoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var points []PricePoint
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "P ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		ts, err := time.Parse("2006-01-02 15:04:05", fields[1]+" "+fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("parse price-db timestamp %q: %w", fields[1]+" "+fields[2], err)
+		}
+		price, err := strconv.ParseFloat(strings.TrimPrefix(fields[4], "$"), 10)
+		if err != nil {
+			return nil, fmt.Errorf("parse price-db amount %q: %w", fields[4], err)
+		}
+		points = append(points, PricePoint{Symbol: fields[3], Time: ts, Price: price})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	return points, nil
+}
+
+// PriceAt returns the most recent quote for symbol at or before at. The
+// second return value is false if no quote exists at or before that time.
+func PriceAt(points []PricePoint, symbol string, at time.Time) (float64, bool) {
+	var best PricePoint
+	found := false
+	for _, p := range points {
+		if p.Symbol != symbol || p.Time.After(at) {
+			continue
+		}
+		if !found || p.Time.After(best.Time) {
+			best = p
+			found = true
+		}
+	}
+	return best.Price, found
+}
+
+// latestQuote returns symbol's most recent quote in points, regardless of
+// how old it is, for sources that serve Quote out of a cached price-db.
+func latestQuote(points []PricePoint, symbol string) (float64, time.Time, bool) {
+	var best PricePoint
+	found := false
+	for _, p := range points {
+		if p.Symbol != symbol {
+			continue
+		}
+		if !found || p.Time.After(best.Time) {
+			best = p
+			found = true
+		}
+	}
+	return best.Price, best.Time, found
+}
+
+// appendPriceDB appends lines, each a pre-formatted price-db entry, to path,
+// creating it if it doesn't already exist.
+func appendPriceDB(path string, lines []string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// formatPriceDBLine renders a quote as a ledger price-db entry, e.g.
+// "P 2023-01-05 00:00:00 AAPL $150.00".
+func formatPriceDBLine(symbol string, price float64, asOf time.Time) string {
+	return fmt.Sprintf("P %s %s %s $%.2f", asOf.Format("2006-01-02"), asOf.Format("15:04:05"), symbol, price)
+}
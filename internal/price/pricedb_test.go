@@ -0,0 +1,64 @@
+package price
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func priceTestTime(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestAppendPriceDBThenReadPriceDBRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.db")
+
+	if err := appendPriceDB(path, []string{
+		formatPriceDBLine("AAPL", 150, priceTestTime("2023-01-05 00:00:00")),
+		formatPriceDBLine("AAPL", 160, priceTestTime("2023-02-05 00:00:00")),
+	}); err != nil {
+		t.Fatalf("appendPriceDB: %v", err)
+	}
+
+	points, err := ReadPriceDB(path)
+	if err != nil {
+		t.Fatalf("ReadPriceDB: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("len(points) = %d, want 2", len(points))
+	}
+
+	price, ts, ok := latestQuote(points, "AAPL")
+	if !ok {
+		t.Fatal("latestQuote returned ok=false")
+	}
+	if price != 160 {
+		t.Errorf("price = %v, want 160", price)
+	}
+	if !ts.Equal(priceTestTime("2023-02-05 00:00:00")) {
+		t.Errorf("ts = %v, want 2023-02-05", ts)
+	}
+}
+
+func TestPriceAtReturnsMostRecentAtOrBefore(t *testing.T) {
+	points := []PricePoint{
+		{Symbol: "AAPL", Time: priceTestTime("2023-01-01 00:00:00"), Price: 100},
+		{Symbol: "AAPL", Time: priceTestTime("2023-03-01 00:00:00"), Price: 120},
+	}
+
+	price, ok := PriceAt(points, "AAPL", priceTestTime("2023-02-01 00:00:00"))
+	if !ok {
+		t.Fatal("PriceAt returned ok=false")
+	}
+	if price != 100 {
+		t.Errorf("price = %v, want 100", price)
+	}
+
+	if _, ok := PriceAt(points, "AAPL", priceTestTime("2022-01-01 00:00:00")); ok {
+		t.Error("PriceAt found a quote before any recorded point")
+	}
+}
@@ -0,0 +1,151 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/config"
+)
+
+// Class identifies the asset class a symbol belongs to, which determines
+// which PriceSource serves its quotes.
+type Class string
+
+const (
+	Equity Class = "equity"
+	Crypto Class = "crypto"
+	FX     Class = "fx"
+)
+
+// Refresher is implemented by sources that refresh their entire backing
+// store in one shot rather than being queried per symbol, e.g. TDASource,
+// which shells out to a binary with no single-symbol query mode.
+type Refresher interface {
+	Refresh() error
+}
+
+// Registry dispatches each symbol to the PriceSource for its Class and
+// writes the results into a ledger price-db file.
+type Registry struct {
+	sources map[Class]PriceSource
+	classes map[string]Class
+}
+
+// NewRegistry builds a Registry from an explicit class->source mapping and
+// an explicit symbol->class mapping. Symbols absent from classes default to
+// Equity.
+func NewRegistry(sources map[Class]PriceSource, classes map[string]Class) *Registry {
+	return &Registry{sources: sources, classes: classes}
+}
+
+// NewRegistryFromConfig builds the Registry helios uses in normal operation:
+// Stooq (or TDA/CSV, per cfg.Price.EquitySource) for equities, Binance for
+// crypto, and a CSV file (per cfg.Price.FXCSVFile, if set) for fx,
+// classifying symbols per cfg.Price.SymbolClasses.
+func NewRegistryFromConfig(cfg config.Config) *Registry {
+	sources := map[Class]PriceSource{
+		Crypto: BinanceSource{},
+	}
+	switch cfg.Price.EquitySource {
+	case "tda":
+		sources[Equity] = TDASource{
+			LedgerBinary: cfg.Ledger.Binary,
+			LedgerFile:   cfg.LedgerFile(),
+			PriceDB:      cfg.PriceDB(),
+			TokenFile:    cfg.TokenFile(),
+		}
+	case "csv":
+		sources[Equity] = CSVSource{Path: cfg.Price.CSVFile}
+	default:
+		sources[Equity] = NewStooqSource()
+	}
+	if cfg.Price.FXCSVFile != "" {
+		sources[FX] = CSVSource{Path: cfg.Price.FXCSVFile}
+	}
+
+	classes := make(map[string]Class, len(cfg.Price.SymbolClasses))
+	for symbol, class := range cfg.Price.SymbolClasses {
+		classes[symbol] = Class(class)
+	}
+
+	return NewRegistry(sources, classes)
+}
+
+// ClassOf returns the Class symbol was configured with, defaulting to
+// Equity.
+func (reg *Registry) ClassOf(symbol string) Class {
+	if class, ok := reg.classes[symbol]; ok {
+		return class
+	}
+	return Equity
+}
+
+// Source returns the PriceSource responsible for symbol, if any is
+// configured for its class.
+func (reg *Registry) Source(symbol string) (PriceSource, bool) {
+	src, ok := reg.sources[reg.ClassOf(symbol)]
+	return src, ok
+}
+
+// IsOpen reports whether symbol's venue is open at now, per its class's
+// source.
+func (reg *Registry) IsOpen(symbol string, now time.Time) bool {
+	src, ok := reg.Source(symbol)
+	if !ok {
+		return false
+	}
+	return IsOpen(src, symbol, now)
+}
+
+// Update quotes every symbol through its class's source and appends the
+// results to priceDB in ledger price-db format. A symbol whose venue is
+// closed at now is skipped unless force is true. A symbol with no
+// configured source is logged and skipped (e.g. an "fx"-classified symbol
+// when cfg.Price.FXCSVFile isn't set). A symbol whose Quote/Refresh call
+// fails is logged and skipped rather than aborting the batch, so one
+// rate-limited or delisted symbol doesn't block every other symbol's
+// refresh; Update only returns an error if writing priceDB itself fails.
+func (reg *Registry) Update(ctx context.Context, priceDB string, symbols []string, now time.Time, force bool) error {
+	refreshed := map[PriceSource]bool{}
+	var lines []string
+
+	for _, symbol := range symbols {
+		src, ok := reg.Source(symbol)
+		if !ok {
+			log.Printf("price: no source configured for %s (class %s)", symbol, reg.ClassOf(symbol))
+			continue
+		}
+		if !force && !IsOpen(src, symbol, now) {
+			continue
+		}
+
+		if refresher, ok := src.(Refresher); ok {
+			if refreshed[src] {
+				continue
+			}
+			if err := refresher.Refresh(); err != nil {
+				log.Printf("price: refresh %s: %v", symbol, err)
+				continue
+			}
+			refreshed[src] = true
+			continue
+		}
+
+		price, asOf, err := src.Quote(ctx, symbol)
+		if err != nil {
+			log.Printf("price: quote %s: %v", symbol, err)
+			continue
+		}
+		lines = append(lines, formatPriceDBLine(symbol, price, asOf))
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	if err := appendPriceDB(priceDB, lines); err != nil {
+		return fmt.Errorf("write price db: %w", err)
+	}
+	return nil
+}
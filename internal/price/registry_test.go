@@ -0,0 +1,127 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeSource is a PriceSource stub for exercising Registry dispatch without
+// hitting any real venue. If failSymbol is set, Quote errors for that one
+// symbol and succeeds for every other.
+type fakeSource struct {
+	price      float64
+	open       bool
+	quoteCalls *int
+	failSymbol string
+}
+
+func (s fakeSource) Quote(ctx context.Context, symbol string) (float64, time.Time, error) {
+	*s.quoteCalls++
+	if symbol == s.failSymbol {
+		return 0, time.Time{}, fmt.Errorf("fakeSource: no quote for %s", symbol)
+	}
+	return s.price, time.Now(), nil
+}
+
+func (s fakeSource) SessionHours(symbol string) (open, close time.Time, tz *time.Location) {
+	now := time.Now().UTC()
+	if s.open {
+		return now.Add(-time.Hour), now.Add(time.Hour), time.UTC
+	}
+	return now.Add(time.Hour), now.Add(2 * time.Hour), time.UTC
+}
+
+func TestRegistryUpdateDispatchesBySymbolClass(t *testing.T) {
+	equityCalls, cryptoCalls := 0, 0
+	reg := NewRegistry(
+		map[Class]PriceSource{
+			Equity: fakeSource{price: 150, open: true, quoteCalls: &equityCalls},
+			Crypto: fakeSource{price: 30000, open: true, quoteCalls: &cryptoCalls},
+		},
+		map[string]Class{"BTC": Crypto},
+	)
+
+	path := filepath.Join(t.TempDir(), "prices.db")
+	if err := reg.Update(context.Background(), path, []string{"AAPL", "BTC"}, time.Now(), false); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if equityCalls != 1 {
+		t.Errorf("equityCalls = %d, want 1", equityCalls)
+	}
+	if cryptoCalls != 1 {
+		t.Errorf("cryptoCalls = %d, want 1", cryptoCalls)
+	}
+
+	points, err := ReadPriceDB(path)
+	if err != nil {
+		t.Fatalf("ReadPriceDB: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("len(points) = %d, want 2", len(points))
+	}
+}
+
+func TestRegistryUpdateSkipsClosedVenueUnlessForced(t *testing.T) {
+	calls := 0
+	reg := NewRegistry(
+		map[Class]PriceSource{Equity: fakeSource{price: 150, open: false, quoteCalls: &calls}},
+		nil,
+	)
+
+	path := filepath.Join(t.TempDir(), "prices.db")
+
+	if err := reg.Update(context.Background(), path, []string{"AAPL"}, time.Now(), false); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("calls = %d, want 0 while venue closed", calls)
+	}
+
+	if err := reg.Update(context.Background(), path, []string{"AAPL"}, time.Now(), true); err != nil {
+		t.Fatalf("Update (forced): %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 after forcing", calls)
+	}
+}
+
+func TestRegistryUpdateSkipsFailedSymbolButWritesTheRest(t *testing.T) {
+	calls := 0
+	reg := NewRegistry(
+		map[Class]PriceSource{Equity: fakeSource{price: 150, open: true, quoteCalls: &calls, failSymbol: "DELISTED"}},
+		nil,
+	)
+
+	path := filepath.Join(t.TempDir(), "prices.db")
+	if err := reg.Update(context.Background(), path, []string{"DELISTED", "AAPL"}, time.Now(), false); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (both symbols attempted)", calls)
+	}
+
+	points, err := ReadPriceDB(path)
+	if err != nil {
+		t.Fatalf("ReadPriceDB: %v", err)
+	}
+	if len(points) != 1 || points[0].Symbol != "AAPL" {
+		t.Fatalf("points = %+v, want just AAPL's quote", points)
+	}
+}
+
+func TestRegistryUpdateSkipsSymbolsWithNoConfiguredSource(t *testing.T) {
+	reg := NewRegistry(map[Class]PriceSource{}, nil)
+
+	path := filepath.Join(t.TempDir(), "prices.db")
+	if err := reg.Update(context.Background(), path, []string{"AAPL"}, time.Now(), true); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := ReadPriceDB(path); err == nil {
+		t.Error("expected no price-db file to be written")
+	}
+}
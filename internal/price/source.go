@@ -0,0 +1,28 @@
+// Package price fetches market quotes from pluggable sources, classified by
+// asset class (equity, crypto, fx), and writes them into helios' ledger
+// price-db file.
+package price
+
+import (
+	"context"
+	"time"
+)
+
+// PriceSource fetches quotes for a single asset class (equity, crypto, fx,
+// ...) and knows when that class's venue is open.
+type PriceSource interface {
+	// Quote returns symbol's latest price and the time it was observed.
+	Quote(ctx context.Context, symbol string) (price float64, asOf time.Time, err error)
+	// SessionHours returns the trading session symbol falls in, as a
+	// [open, close) window in tz. A source that trades continuously (e.g.
+	// crypto) returns a window that always contains the current time (see
+	// alwaysOpenSessionHours).
+	SessionHours(symbol string) (open, close time.Time, tz *time.Location)
+}
+
+// IsOpen reports whether src's session for symbol contains now.
+func IsOpen(src PriceSource, symbol string, now time.Time) bool {
+	open, close, tz := src.SessionHours(symbol)
+	local := now.In(tz)
+	return !local.Before(open) && local.Before(close)
+}
@@ -0,0 +1,73 @@
+package price
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StooqSource fetches delayed equity quotes from Stooq's free CSV endpoint.
+// It needs no API key, which makes it a reasonable default for the "equity"
+// class when no brokerage integration is configured.
+type StooqSource struct {
+	HTTPClient *http.Client
+}
+
+// NewStooqSource returns a StooqSource using http.DefaultClient.
+func NewStooqSource() StooqSource {
+	return StooqSource{HTTPClient: http.DefaultClient}
+}
+
+func (s StooqSource) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Quote fetches symbol's last trade price and timestamp from Stooq.
+func (s StooqSource) Quote(ctx context.Context, symbol string) (float64, time.Time, error) {
+	url := fmt.Sprintf("https://stooq.com/q/l/?s=%s.us&f=sd2t2c&h&e=csv", strings.ToLower(symbol))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("stooq quote %s: %w", symbol, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, time.Time{}, fmt.Errorf("stooq quote %s: unexpected status %s", symbol, resp.Status)
+	}
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("stooq quote %s: %w", symbol, err)
+	}
+	if len(records) < 2 || len(records[1]) < 4 {
+		return 0, time.Time{}, fmt.Errorf("stooq quote %s: no data returned", symbol)
+	}
+
+	row := records[1] // Symbol,Date,Time,Close
+	price, err := strconv.ParseFloat(row[3], 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("stooq quote %s: parse price %q: %w", symbol, row[3], err)
+	}
+	asOf, err := time.Parse("2006-01-02 15:04:05", row[1]+" "+row[2])
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("stooq quote %s: parse timestamp: %w", symbol, err)
+	}
+	return price, asOf, nil
+}
+
+// SessionHours reports the regular NYSE session, since Stooq's free feed
+// covers US-listed equities.
+func (s StooqSource) SessionHours(symbol string) (open, close time.Time, tz *time.Location) {
+	return nyseSessionHours()
+}
@@ -0,0 +1,52 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// TDASource wraps the existing tdaLedgerUpdate binary. It refreshes the
+// entire price-db in one shot (the binary has no single-symbol query mode),
+// then serves Quote out of that freshly written file.
+type TDASource struct {
+	LedgerBinary string
+	LedgerFile   string
+	PriceDB      string
+	TokenFile    string
+}
+
+// Refresh runs tdaLedgerUpdate to bring PriceDB up to date for every
+// security held in LedgerFile.
+func (s TDASource) Refresh() error {
+	cmd := exec.Command("tdaLedgerUpdate",
+		"-f", s.LedgerFile,
+		"-p", s.PriceDB,
+		"-b", s.LedgerBinary,
+		"-afile", s.TokenFile)
+	if out, err := cmd.Output(); err != nil {
+		return fmt.Errorf("%s %w", string(out), err)
+	}
+	return nil
+}
+
+// Quote reads symbol's latest quote out of PriceDB. Callers should call
+// Refresh first during market hours to avoid serving a stale quote.
+func (s TDASource) Quote(ctx context.Context, symbol string) (float64, time.Time, error) {
+	points, err := ReadPriceDB(s.PriceDB)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	price, asOf, ok := latestQuote(points, symbol)
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("no quote for %s in %s", symbol, s.PriceDB)
+	}
+	return price, asOf, nil
+}
+
+// SessionHours reports the regular NYSE session, since TDA equities follow
+// US market hours.
+func (s TDASource) SessionHours(symbol string) (open, close time.Time, tz *time.Location) {
+	return nyseSessionHours()
+}
@@ -0,0 +1,70 @@
+// Package reposync keeps a local git checkout of the user's ledger repo up
+// to date.
+package reposync
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// Options describes where to check the repo out and how to authenticate to
+// it.
+type Options struct {
+	Dir      string
+	URL      string
+	Branch   string
+	Username string
+	Token    string
+}
+
+// Pull clones opts.URL into opts.Dir if it isn't already a checkout, then
+// fast-forwards it to the latest commit on opts.Branch.
+func Pull(opts Options) error {
+	auth := http.BasicAuth{
+		Username: opts.Username,
+		Password: opts.Token,
+	}
+
+	repo, err := git.PlainOpen(opts.Dir)
+	if err != nil {
+		if err != git.ErrRepositoryNotExists {
+			return err
+		}
+		repo, err = git.PlainClone(opts.Dir, false, &git.CloneOptions{
+			URL:  opts.URL,
+			Auth: &auth,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	err = w.Pull(&git.PullOptions{
+		ReferenceName: plumbing.ReferenceName("refs/heads/" + opts.Branch),
+		Auth:          &auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return err
+	}
+
+	return nil
+}
+
+// Head returns the commit hash of dir's current HEAD.
+func Head(dir string) (string, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return "", err
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
@@ -0,0 +1,76 @@
+// Package state persists helios' run state — the last successful sync
+// time, the last observed balances, and the ledger commit that was synced —
+// in a local bbolt file so a restart can detect and backfill any gap.
+package state
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("helios")
+var snapshotKey = []byte("snapshot")
+
+// Snapshot is everything helios needs to remember between runs.
+type Snapshot struct {
+	LastSync   time.Time          `json:"last_sync"`
+	LedgerHead string             `json:"ledger_head"`
+	Basis      map[string]float64 `json:"basis"`
+	Market     map[string]float64 `json:"market"`
+}
+
+// Store wraps a bbolt database holding a single Snapshot.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens (creating if necessary) the state file at path.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Load returns the persisted Snapshot, or a zero Snapshot if none has been
+// saved yet.
+func (s *Store) Load() (Snapshot, error) {
+	var snap Snapshot
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(bucketName).Get(snapshotKey)
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &snap)
+	})
+	return snap, err
+}
+
+// Save persists snap, overwriting whatever Snapshot was saved previously.
+func (s *Store) Save(snap Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Put(snapshotKey, data)
+	})
+}
@@ -0,0 +1,41 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+
+	empty, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load (empty): %v", err)
+	}
+	if !empty.LastSync.IsZero() {
+		t.Fatalf("expected zero Snapshot, got %+v", empty)
+	}
+
+	want := Snapshot{
+		LastSync:   time.Now().UTC().Truncate(time.Second),
+		LedgerHead: "deadbeef",
+		Basis:      map[string]float64{"ira": 100},
+		Market:     map[string]float64{"ira": 110},
+	}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !got.LastSync.Equal(want.LastSync) || got.LedgerHead != want.LedgerHead || got.Market["ira"] != want.Market["ira"] {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
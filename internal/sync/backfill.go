@@ -0,0 +1,96 @@
+package sync
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/config"
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// maxBackfillDays bounds how far Backfill will replay after a long outage,
+// so a host that's been off for a year doesn't trigger thousands of
+// `ledger` invocations.
+const maxBackfillDays = 90
+
+// Backfill walks every day after since up to and including until (at most
+// the most recent maxBackfillDays of them) and computes what the "balance"
+// points for that day would have been, using the reader's Bal with
+// `-B --end <date>` for basis and `-V --now <date>` for market value. It's used to fill the gap in Influx left by a host that was
+// offline past its usual sync interval.
+//
+// prevMarket carries the last known market value per account so the first
+// backfilled point (and only that one) can still report a delta_market
+// relative to history. No-op days (basis and market both unchanged from the
+// prior day) are skipped so a quiet weekend doesn't write a wall of
+// identical points.
+func Backfill(cfg config.Config, r ledger.Reader, since, until time.Time, prevMarket map[string]float64) ([]*write.Point, error) {
+	var points []*write.Point
+
+	lastBasis := map[string]float64{}
+	lastMarket := map[string]float64{}
+	for k, v := range prevMarket {
+		lastMarket[k] = v
+	}
+
+	start := since.AddDate(0, 0, 1)
+	if wanted := daysBetween(since, until); wanted > maxBackfillDays {
+		log.Printf("backfill: %s to %s is %d days, capping at %d (dropping the oldest %d)",
+			since.Format("2006-01-02"), until.Format("2006-01-02"), wanted, maxBackfillDays, wanted-maxBackfillDays)
+		start = since.AddDate(0, 0, wanted-maxBackfillDays+1)
+	}
+
+	days := 0
+	for d := start; !d.After(until) && days < maxBackfillDays; d = d.AddDate(0, 0, 1) {
+		days++
+		dateArg := d.Format("2006-01-02")
+
+		for _, acct := range []struct {
+			name    string
+			account string
+		}{{"ira", IraAccount}, {"tax", TaxAccount}} {
+			basisBal, err := r.Bal("-B", "--end", dateArg, acct.account)
+			if err != nil {
+				return nil, fmt.Errorf("backfill %s basis %s: %w", acct.name, dateArg, err)
+			}
+			marketBal, err := r.Bal("--price-db", cfg.PriceDB(), "-V", "--now", dateArg, acct.account)
+			if err != nil {
+				return nil, fmt.Errorf("backfill %s market %s: %w", acct.name, dateArg, err)
+			}
+			basis, market := ledger.Total(basisBal), ledger.Total(marketBal)
+
+			if basis == lastBasis[acct.name] && market == lastMarket[acct.name] {
+				continue
+			}
+
+			delta := market - lastMarket[acct.name]
+			lastBasis[acct.name] = basis
+			lastMarket[acct.name] = market
+
+			p := influxdb2.NewPointWithMeasurement("balance").
+				AddTag("account", acct.name).
+				AddField("basis", basis).
+				AddField("market", market).
+				AddField("gain", market-basis).
+				AddField("delta_market", delta).
+				SetTime(d)
+			points = append(points, p)
+		}
+	}
+
+	return points, nil
+}
+
+// daysBetween counts the days after since up to and including until, the
+// same window Backfill walks, so callers can tell whether
+// maxBackfillDays is about to truncate it.
+func daysBetween(since, until time.Time) int {
+	days := 0
+	for d := since.AddDate(0, 0, 1); !d.After(until); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/config"
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	"github.com/adityaxdiwakar/helios/internal/reposync"
+	"github.com/adityaxdiwakar/helios/internal/state"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// staleThreshold is how far past the last recorded sync "now" has to be
+// before a run is treated as recovering from an outage and triggers a
+// backfill.
+const staleThreshold = 2 * time.Hour
+
+// RunWithState pulls the ledger repo, backfills Influx for any gap since
+// the last successful run, collects current balances, and pushes both the
+// backfill and the live point to Influx, saving the new state.Snapshot on
+// success.
+func RunWithState(cfg config.Config) error {
+	store, err := state.Open(cfg.StateFile())
+	if err != nil {
+		return fmt.Errorf("open state: %w", err)
+	}
+	defer store.Close()
+
+	snap, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	if err := reposync.Pull(reposync.Options{
+		Dir:      cfg.RepoDir(),
+		URL:      cfg.Repo.URL,
+		Branch:   cfg.Repo.Branch,
+		Username: cfg.Repo.Username,
+		Token:    cfg.Repo.Token,
+	}); err != nil {
+		return fmt.Errorf("pull repo: %w", err)
+	}
+
+	head, err := reposync.Head(cfg.RepoDir())
+	if err != nil {
+		return fmt.Errorf("read repo head: %w", err)
+	}
+
+	now := time.Now()
+	client := influxdb2.NewClient(cfg.Influx.URL, cfg.Influx.Token)
+	defer client.Close()
+	writeApi := client.WriteAPI(cfg.Influx.Org, cfg.Influx.Bucket)
+
+	if !snap.LastSync.IsZero() && now.Sub(snap.LastSync) > staleThreshold {
+		r := ledger.NewReader(cfg.Ledger.Binary, cfg.LedgerFile())
+		points, err := Backfill(cfg, r, snap.LastSync, now, snap.Market)
+		if err != nil {
+			return fmt.Errorf("backfill: %w", err)
+		}
+		for _, p := range points {
+			writeApi.WritePoint(p)
+		}
+	}
+
+	res, err := Collect(cfg, Options{PullRepo: false, UpdatePrices: true})
+	if err != nil {
+		return fmt.Errorf("collect: %w", err)
+	}
+
+	market := map[string]float64{"ira": res.Ira.Market, "tax": res.Tax.Market}
+	basis := map[string]float64{"ira": res.Ira.Basis, "tax": res.Tax.Basis}
+
+	if basis["ira"] != snap.Basis["ira"] || market["ira"] != snap.Market["ira"] {
+		writeApi.WritePoint(pointWithDelta(res.Ira, market["ira"]-snap.Market["ira"]))
+	}
+	if basis["tax"] != snap.Basis["tax"] || market["tax"] != snap.Market["tax"] {
+		writeApi.WritePoint(pointWithDelta(res.Tax, market["tax"]-snap.Market["tax"]))
+	}
+	for ticker, bal := range res.CostBasis {
+		p := influxdb2.NewPointWithMeasurement("balance").
+			AddTag("account", ticker).
+			AddField("basis", bal.Basis).
+			AddField("market", bal.Market).
+			AddField("gain-percent", bal.Gain/bal.Basis)
+		writeApi.WritePoint(p)
+	}
+
+	writeApi.Flush()
+
+	return store.Save(state.Snapshot{
+		LastSync:   now,
+		LedgerHead: head,
+		Basis:      basis,
+		Market:     market,
+	})
+}
+
+func pointWithDelta(b AccountBalance, delta float64) *write.Point {
+	return influxdb2.NewPointWithMeasurement("balance").
+		AddTag("account", b.Name).
+		AddField("basis", b.Basis).
+		AddField("market", b.Market).
+		AddField("gain", b.Gain).
+		AddField("delta_market", delta)
+}
@@ -0,0 +1,172 @@
+// Package sync computes account balances from the ledger and, optionally,
+// publishes them to InfluxDB. It is the shared core behind the `sync` and
+// `report` subcommands.
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adityaxdiwakar/helios/internal/config"
+	"github.com/adityaxdiwakar/helios/internal/ledger"
+	"github.com/adityaxdiwakar/helios/internal/price"
+	"github.com/adityaxdiwakar/helios/internal/reposync"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+const (
+	IraAccount = "Assets:Investments:IRA"
+	TaxAccount = "Assets:Investments:Fidelity"
+	Accounts   = "Assets:Investments"
+)
+
+// AccountBalance is the basis/market/gain for one tracked account.
+type AccountBalance struct {
+	Name   string
+	Basis  float64
+	Market float64
+	Gain   float64
+}
+
+// Result is everything a sync run computed, ready to be printed or pushed.
+type Result struct {
+	Ira       AccountBalance
+	Tax       AccountBalance
+	CostBasis map[string]AccountBalance
+}
+
+// Options controls which side effects a run performs.
+type Options struct {
+	// PullRepo re-clones/pulls the ledger git repo before reading it.
+	PullRepo bool
+	// UpdatePrices refreshes the local price-db (during market hours only).
+	UpdatePrices bool
+}
+
+// Collect reads the ledger and price-db under cfg and returns the computed
+// balances. It never talks to InfluxDB; callers decide what to do with the
+// result.
+func Collect(cfg config.Config, opts Options) (*Result, error) {
+	if opts.PullRepo {
+		if err := reposync.Pull(reposync.Options{
+			Dir:      cfg.RepoDir(),
+			URL:      cfg.Repo.URL,
+			Branch:   cfg.Repo.Branch,
+			Username: cfg.Repo.Username,
+			Token:    cfg.Repo.Token,
+		}); err != nil {
+			return nil, fmt.Errorf("pull repo: %w", err)
+		}
+	}
+
+	r := ledger.NewReader(cfg.Ledger.Binary, cfg.LedgerFile())
+
+	basisIraBal, err := r.Bal("-B", IraAccount)
+	if err != nil {
+		return nil, fmt.Errorf("ira basis: %w", err)
+	}
+	basisTaxBal, err := r.Bal("-B", TaxAccount)
+	if err != nil {
+		return nil, fmt.Errorf("tax basis: %w", err)
+	}
+	basisIra, basisTax := ledger.Total(basisIraBal), ledger.Total(basisTaxBal)
+
+	costBasisBals, err := r.Bal(Accounts, "--average-lot-prices")
+	if err != nil {
+		costBasisBals = nil
+	}
+
+	if opts.UpdatePrices {
+		reg := price.NewRegistryFromConfig(cfg)
+		if err := reg.Update(context.Background(), cfg.PriceDB(), CostBasisTickers(costBasisBals), time.Now(), false); err != nil {
+			return nil, fmt.Errorf("update price db: %w", err)
+		}
+	}
+
+	marketIraBal, err := r.Bal("--price-db", cfg.PriceDB(), "-V", IraAccount)
+	if err != nil {
+		return nil, fmt.Errorf("ira market: %w", err)
+	}
+	marketTaxBal, err := r.Bal("--price-db", cfg.PriceDB(), "-V", TaxAccount)
+	if err != nil {
+		return nil, fmt.Errorf("tax market: %w", err)
+	}
+	marketIra, marketTax := ledger.Total(marketIraBal), ledger.Total(marketTaxBal)
+
+	res := &Result{
+		Ira:       AccountBalance{Name: "ira", Basis: basisIra, Market: marketIra, Gain: marketIra - basisIra},
+		Tax:       AccountBalance{Name: "tax", Basis: basisTax, Market: marketTax, Gain: marketTax - basisTax},
+		CostBasis: map[string]AccountBalance{},
+	}
+
+	for _, bal := range costBasisBals {
+		if len(bal.Amounts) == 0 || bal.Amounts[0].Cost == nil {
+			continue
+		}
+		ticker := bal.Amounts[0].Commodity
+		basis := bal.Amounts[0].Quantity * *bal.Amounts[0].Cost
+
+		marketBal, err := r.Bal(
+			"--price-db",
+			cfg.PriceDB(),
+			"-V",
+			fmt.Sprintf("Allocation:Equities:%s", strings.ToUpper(ticker)),
+		)
+		if err != nil {
+			continue
+		}
+		value := ledger.Total(marketBal)
+		res.CostBasis[ticker] = AccountBalance{Name: ticker, Basis: basis, Market: value, Gain: value - basis}
+	}
+
+	return res, nil
+}
+
+// CostBasisTickers returns the distinct commodities held across bals that
+// carry a per-lot cost, i.e. the symbols helios needs live quotes for.
+func CostBasisTickers(bals []ledger.Balance) []string {
+	var tickers []string
+	seen := map[string]bool{}
+	for _, bal := range bals {
+		if len(bal.Amounts) == 0 || bal.Amounts[0].Cost == nil {
+			continue
+		}
+		ticker := bal.Amounts[0].Commodity
+		if seen[ticker] {
+			continue
+		}
+		seen[ticker] = true
+		tickers = append(tickers, ticker)
+	}
+	return tickers
+}
+
+// Push writes r to InfluxDB using cfg's connection settings.
+func Push(cfg config.Config, r *Result) error {
+	client := influxdb2.NewClient(cfg.Influx.URL, cfg.Influx.Token)
+	defer client.Close()
+	writeApi := client.WriteAPI(cfg.Influx.Org, cfg.Influx.Bucket)
+
+	for ticker, bal := range r.CostBasis {
+		p := influxdb2.NewPointWithMeasurement("balance").
+			AddTag("account", ticker).
+			AddField("basis", bal.Basis).
+			AddField("market", bal.Market).
+			AddField("gain-percent", bal.Gain/bal.Basis)
+		writeApi.WritePoint(p)
+	}
+
+	for _, bal := range []AccountBalance{r.Ira, r.Tax} {
+		p := influxdb2.NewPointWithMeasurement("balance").
+			AddTag("account", bal.Name).
+			AddField("basis", bal.Basis).
+			AddField("market", bal.Market).
+			AddField("gain", bal.Gain)
+		writeApi.WritePoint(p)
+	}
+
+	writeApi.Flush()
+	return nil
+}